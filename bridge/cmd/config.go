@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/metis-seq/themis/helper"
+)
+
+const (
+	configFlag = "config"
+
+	envPrefix = "THEMIS_BRIDGE"
+)
+
+// decorateWithConfigFlag registers the --config persistent flag used to
+// point at an on-disk bridge config file.
+func (bc *BridgeContext) decorateWithConfigFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().String(configFlag, "", "path to bridge config file (default <home>/config/bridge.{yaml,toml})")
+}
+
+// initEnvBinding wires this context's viper instance to read overrides
+// from THEMIS_BRIDGE_-prefixed environment variables, e.g.
+// THEMIS_BRIDGE_BRIDGE_DB for --bridge-db.
+func (bc *BridgeContext) initEnvBinding() {
+	bc.Viper.SetEnvPrefix(envPrefix)
+	bc.Viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_", ".", "_"))
+	bc.Viper.AutomaticEnv()
+}
+
+// loadConfigFile reads a YAML or TOML bridge config file into this
+// context's viper instance, if one is available. It looks first at the
+// --config flag, then at <home>/config/bridge.yaml and
+// <home>/config/bridge.toml. It is not an error for no config file to be
+// found; it is an error for the config file to fail to parse, or to
+// contain keys that don't correspond to any registered flag.
+func (bc *BridgeContext) loadConfigFile(cmd *cobra.Command) error {
+	configPath, _ := cmd.Flags().GetString(configFlag)
+	homeValue, _ := cmd.Flags().GetString(helper.HomeFlag)
+
+	if configPath == "" {
+		for _, ext := range []string{"yaml", "toml"} {
+			candidate := filepath.Join(homeValue, "config", "bridge."+ext)
+			if _, err := os.Stat(candidate); err == nil {
+				configPath = candidate
+				break
+			}
+		}
+	}
+
+	if configPath == "" {
+		return nil
+	}
+
+	bc.Viper.SetConfigFile(configPath)
+
+	if err := bc.Viper.ReadInConfig(); err != nil {
+		return fmt.Errorf("failed to read bridge config %s: %w", configPath, err)
+	}
+
+	return bc.validateConfigKeys(cmd)
+}
+
+// validateConfigKeys reports a clear error listing any config file key
+// that doesn't correspond to a flag registered on cmd, so a typo in a
+// config file fails loudly instead of silently doing nothing.
+func (bc *BridgeContext) validateConfigKeys(cmd *cobra.Command) error {
+	var unknown []string
+
+	for _, key := range bc.Viper.AllKeys() {
+		if cmd.Flags().Lookup(key) == nil {
+			unknown = append(unknown, key)
+		}
+	}
+
+	if len(unknown) == 0 {
+		return nil
+	}
+
+	sort.Strings(unknown)
+
+	return fmt.Errorf("unknown key(s) in bridge config file: %s", strings.Join(unknown, ", "))
+}
+
+// resolveString returns the value for flagName honoring the precedence
+// explicit flag > env var > config file > flag default: an explicitly
+// set flag always wins, otherwise this context's viper (which layers env
+// vars over the config file) is consulted, falling back to the flag's own
+// default value.
+func (bc *BridgeContext) resolveString(cmd *cobra.Command, flagName string) string {
+	flag := cmd.Flags().Lookup(flagName)
+
+	if flag != nil && flag.Changed {
+		return flag.Value.String()
+	}
+
+	if v := bc.Viper.GetString(flagName); v != "" {
+		return v
+	}
+
+	if flag != nil {
+		return flag.Value.String()
+	}
+
+	return ""
+}
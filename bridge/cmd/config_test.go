@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	tendermintLogger "github.com/tendermint/tendermint/libs/log"
+)
+
+// TestResolveString_Precedence checks that an explicitly set flag beats a
+// viper-backed value (env var or config file), and that a viper-backed
+// value beats the flag's own default.
+func TestResolveString_Precedence(t *testing.T) {
+	bc := NewBridgeContext(nil, tendermintLogger.NewNopLogger())
+
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().String(metisChainIDFlag, "default-chain", "")
+
+	if got := bc.resolveString(cmd, metisChainIDFlag); got != "default-chain" {
+		t.Fatalf("resolveString() = %q, want flag default %q", got, "default-chain")
+	}
+
+	bc.Viper.Set(metisChainIDFlag, "viper-chain")
+
+	if got := bc.resolveString(cmd, metisChainIDFlag); got != "viper-chain" {
+		t.Fatalf("resolveString() = %q, want viper-backed value %q", got, "viper-chain")
+	}
+
+	if err := cmd.Flags().Set(metisChainIDFlag, "flag-chain"); err != nil {
+		t.Fatalf("failed to set flag: %v", err)
+	}
+
+	if got := bc.resolveString(cmd, metisChainIDFlag); got != "flag-chain" {
+		t.Fatalf("resolveString() = %q, want explicit flag value %q", got, "flag-chain")
+	}
+}
+
+// TestValidateConfigKeys_UnknownKey ensures an unrecognized config file
+// key produces a clear error rather than being silently ignored.
+func TestValidateConfigKeys_UnknownKey(t *testing.T) {
+	bc := NewBridgeContext(nil, tendermintLogger.NewNopLogger())
+
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().String(metisChainIDFlag, "default-chain", "")
+
+	bc.Viper.Set("not-a-real-flag", "value")
+
+	if err := bc.validateConfigKeys(cmd); err == nil {
+		t.Fatal("expected an error for an unknown config key, got nil")
+	}
+}
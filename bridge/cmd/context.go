@@ -0,0 +1,318 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	tendermintLogger "github.com/tendermint/tendermint/libs/log"
+	rpcserver "github.com/tendermint/tendermint/rpc/lib/server"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/metis-seq/themis/bridge/db"
+	"github.com/metis-seq/themis/bridge/health"
+	"github.com/metis-seq/themis/helper"
+	"github.com/metis-seq/themis/version"
+)
+
+// BridgeContext carries everything needed to build and run the bridge
+// daemon's command tree. Earlier versions of this package kept this state
+// (rootCmd, metricsServer, logger) as package-level globals, which made the
+// bridge impossible to embed or construct twice in the same process - a
+// second initMetrics call would panic registering "/metrics" on the
+// default mux a second time. BridgeContext owns its own viper instance and
+// metrics mux, so independent contexts never interfere with one another.
+type BridgeContext struct {
+	Viper  *viper.Viper
+	Logger tendermintLogger.Logger
+
+	// HomeDir mirrors the --home flag once the command tree has parsed
+	// it; it is populated by initTendermintViperConfig.
+	HomeDir string
+
+	// MetricsServer is the HTTP server serving /metrics for this context.
+	// It is nil until initMetrics has run.
+	MetricsServer *http.Server
+
+	// TracerProvider and MeterProvider are populated by initTracing when
+	// --otlp-endpoint is set; both stay nil otherwise.
+	TracerProvider *sdktrace.TracerProvider
+	MeterProvider  *sdkmetric.MeterProvider
+
+	// DB is the bridge's storage handle, opened through the db factory by
+	// openBridgeDB using --bridge-db-backend/--bridge-db-dsn/
+	// --bridge-db-options. It is nil until openBridgeDB has run.
+	DB db.Backend
+
+	// LastProcessedHeight is the last rootchain block height the bridge
+	// has fully processed, as stored by callers of
+	// LastProcessedHeight.Store; the bridge processors do not store to it.
+	// The "block-lag" readiness check compares it against Tendermint's
+	// head height. Zero means "no height has been reported", in which case
+	// the lag check passes vacuously rather than failing readiness.
+	LastProcessedHeight atomic.Int64
+
+	// Health is the registry bridge subsystems (rootchain listener, span
+	// processor, checkpoint processor, sqlite writer) register readiness
+	// Checkers against; it backs /healthz, /readyz, and /livez.
+	Health *health.Registry
+
+	// HealthServer is non-nil only when --health-server-addr requests a
+	// dedicated probe server instead of serving probes off MetricsServer.
+	HealthServer *http.Server
+
+	metricsMux *http.ServeMux
+}
+
+// NewBridgeContext returns a BridgeContext ready to decorate a fresh
+// *cobra.Command tree. A nil viper or logger falls back to a freshly
+// created viper.Viper and the package default logger, respectively.
+func NewBridgeContext(v *viper.Viper, loggerInstance tendermintLogger.Logger) *BridgeContext {
+	if v == nil {
+		v = viper.New()
+	}
+
+	if loggerInstance == nil {
+		loggerInstance = helper.Logger.With("module", "bridge/cmd/")
+	}
+
+	return &BridgeContext{
+		Viper:      v,
+		Logger:     loggerInstance,
+		Health:     health.NewRegistry(),
+		metricsMux: http.NewServeMux(),
+	}
+}
+
+// BridgeCommands returns a fresh command tree for the bridge service
+// wired to this context. Each call returns a new *cobra.Command, so two
+// BridgeContexts in the same process, each with its own BridgeCommands
+// tree, yield two daemons that do not share flags, viper state, or a
+// metrics mux. Trees built from the same BridgeContext share its viper
+// instance and metrics mux.
+func (bc *BridgeContext) BridgeCommands(caller string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "themis-bridge",
+		Aliases: []string{"bridge"},
+		Short:   "Themis bridge deamon",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if cmd.Use == version.Cmd.Use {
+				return nil
+			}
+
+			// initialize tendermint viper config
+			bc.initTendermintViperConfig(cmd)
+
+			// init metrics server
+			bc.initMetrics(cmd)
+
+			// init OTLP tracing/metrics export
+			if err := bc.initTracing(cmd); err != nil {
+				bc.Logger.Error("failed to initialize tracing", "error", err)
+			}
+
+			// open the bridge db through the backend factory and wire it
+			// into a readiness check
+			if err := bc.openBridgeDB(cmd); err != nil {
+				return err
+			}
+
+			bc.registerHealthCheckers(cmd)
+
+			// mount /healthz, /readyz, /livez
+			bc.initHealth(cmd)
+
+			return nil
+		},
+		PostRunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+			defer cancel()
+
+			bc.Health.Drain()
+
+			if err := bc.shutdownTracing(ctx); err != nil {
+				bc.Logger.Error("failed to shut down tracing", "error", err)
+			}
+
+			if bc.DB != nil {
+				if err := bc.DB.Close(); err != nil {
+					bc.Logger.Error("failed to close bridge db", "error", err)
+				}
+			}
+
+			if bc.HealthServer != nil {
+				if err := bc.HealthServer.Shutdown(ctx); err != nil {
+					bc.Logger.Error("failed to shut down health server", "error", err)
+				}
+			}
+
+			if bc.MetricsServer == nil {
+				return nil
+			}
+
+			return bc.MetricsServer.Shutdown(ctx)
+		},
+	}
+
+	bc.DecorateWithBridgeRootFlags(cmd, caller)
+	bc.decorateWithTracingFlags(cmd)
+	bc.decorateWithConfigFlag(cmd)
+	bc.decorateWithDBFlags(cmd)
+	bc.decorateWithHealthFlags(cmd)
+
+	cmd.AddCommand(bc.dbCommands())
+
+	return cmd
+}
+
+// DecorateWithBridgeRootFlags is called when bridge flags need to be added
+// to cmd. The node, home, bridge-db and metis-chain-id flags are bound
+// against this context's viper instance; the others are not bound.
+func (bc *BridgeContext) DecorateWithBridgeRootFlags(cmd *cobra.Command, caller string) {
+	cmd.PersistentFlags().StringP(helper.TendermintNodeFlag, "n", helper.DefaultTendermintNode, "Node to connect to")
+
+	if err := bc.Viper.BindPFlag(helper.TendermintNodeFlag, cmd.PersistentFlags().Lookup(helper.TendermintNodeFlag)); err != nil {
+		bc.Logger.Error(fmt.Sprintf("%v | BindPFlag | %v", caller, helper.TendermintNodeFlag), "Error", err)
+	}
+
+	cmd.PersistentFlags().String(helper.HomeFlag, helper.DefaultNodeHome, "directory for config and data")
+
+	if err := bc.Viper.BindPFlag(helper.HomeFlag, cmd.PersistentFlags().Lookup(helper.HomeFlag)); err != nil {
+		bc.Logger.Error(fmt.Sprintf("%v | BindPFlag | %v", caller, helper.HomeFlag), "Error", err)
+	}
+
+	// bridge storage db
+	cmd.PersistentFlags().String(
+		bridgeDBFlag,
+		"",
+		"Bridge db path (default <home>/bridge/storage)",
+	)
+
+	if err := bc.Viper.BindPFlag(bridgeDBFlag, cmd.PersistentFlags().Lookup(bridgeDBFlag)); err != nil {
+		bc.Logger.Error(fmt.Sprintf("%v | BindPFlag | %v", caller, bridgeDBFlag), "Error", err)
+	}
+
+	// bridge chain id
+	cmd.PersistentFlags().String(
+		metisChainIDFlag,
+		helper.DefaultMetisChainID,
+		"Metis chain id",
+	)
+
+	// bridge logging type
+	cmd.PersistentFlags().String(
+		logsTypeFlag,
+		helper.DefaultLogsType,
+		"Use json logger",
+	)
+
+	// bridge metrics server listen addr
+	cmd.PersistentFlags().String(
+		metricsServerFlag,
+		helper.DefaultMetricsListenAddr,
+		"Metrics server listen addr, default to :2112",
+	)
+
+	// bridge rpc server listen addr
+	cmd.PersistentFlags().String(
+		rpcServerFlag,
+		helper.DefaultRPCListenAddr,
+		"RPC server listen addr, default to :8646",
+	)
+
+	if err := bc.Viper.BindPFlag(metisChainIDFlag, cmd.PersistentFlags().Lookup(metisChainIDFlag)); err != nil {
+		bc.Logger.Error(fmt.Sprintf("%v | BindPFlag | %v", caller, metisChainIDFlag), "Error", err)
+	}
+}
+
+// initMetrics initializes this context's metrics server with the default
+// Prometheus handler, mounted on a mux owned by the context rather than
+// http.DefaultServeMux so that multiple contexts can run concurrently.
+func (bc *BridgeContext) initMetrics(cmd *cobra.Command) {
+	cfg := rpcserver.DefaultConfig()
+	metricsServerListenAddr := bc.Viper.GetString(metricsServerFlag)
+
+	if bc.metricsMux == nil {
+		bc.metricsMux = http.NewServeMux()
+	}
+
+	bc.metricsMux.Handle("/metrics", promhttp.Handler())
+
+	bc.MetricsServer = &http.Server{
+		Addr:              metricsServerListenAddr,
+		Handler:           bc.metricsMux,
+		ReadTimeout:       cfg.ReadTimeout,
+		ReadHeaderTimeout: cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		MaxHeaderBytes:    cfg.MaxHeaderBytes,
+	}
+
+	go func() {
+		if err := bc.MetricsServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			bc.Logger.Error("failed to start metrics server", "error", err)
+			os.Exit(1)
+		}
+	}()
+}
+
+// AdjustBridgeDBValue is called to set the appropriate bridge db path on
+// this context's viper instance.
+func (bc *BridgeContext) AdjustBridgeDBValue(cmd *cobra.Command) {
+	tendermintNode := bc.resolveString(cmd, helper.TendermintNodeFlag)
+	homeValue := bc.resolveString(cmd, helper.HomeFlag)
+	withThemisConfigValue, _ := cmd.Flags().GetString(helper.WithThemisConfigFlag)
+	bridgeDBValue := bc.resolveString(cmd, bridgeDBFlag)
+	bridgeSqliteDBValue, _ := cmd.Flags().GetString(bridgeSqliteDBFlag)
+	metisChainIDValue := bc.resolveString(cmd, metisChainIDFlag)
+	logsTypeValue := bc.resolveString(cmd, logsTypeFlag)
+	metricsServerAddrValue := bc.resolveString(cmd, metricsServerFlag)
+	rpcServerAddrValue := bc.resolveString(cmd, rpcServerFlag)
+
+	// bridge-db directory (default storage)
+	if bridgeDBValue == "" {
+		bridgeDBValue = filepath.Join(homeValue, "bridge", "storage")
+	}
+
+	if bridgeSqliteDBValue == "" {
+		bridgeSqliteDBValue = filepath.Join(homeValue, "bridge", "sqlite")
+	}
+
+	bc.HomeDir = homeValue
+
+	// set to this context's viper, not the global singleton
+	bc.Viper.Set(helper.TendermintNodeFlag, tendermintNode)
+	bc.Viper.Set(helper.HomeFlag, homeValue)
+	bc.Viper.Set(helper.WithThemisConfigFlag, withThemisConfigValue)
+	bc.Viper.Set(bridgeDBFlag, bridgeDBValue)
+	bc.Viper.Set(bridgeSqliteDBFlag, bridgeSqliteDBValue)
+	bc.Viper.Set(metisChainIDFlag, metisChainIDValue)
+	bc.Viper.Set(logsTypeFlag, logsTypeValue)
+	bc.Viper.Set(metricsServerFlag, metricsServerAddrValue)
+	bc.Viper.Set(rpcServerFlag, rpcServerAddrValue)
+}
+
+// initTendermintViperConfig sets this context's viper configuration
+// needed to run themis.
+func (bc *BridgeContext) initTendermintViperConfig(cmd *cobra.Command) {
+	// bind THEMIS_BRIDGE_* env vars and load <home>/config/bridge.{yaml,toml}
+	// so flag precedence is: explicit flag > env var > config file > default
+	bc.initEnvBinding()
+
+	if err := bc.loadConfigFile(cmd); err != nil {
+		bc.Logger.Error("failed to load bridge config file", "error", err)
+	}
+
+	// set appropriate bridge DB
+	bc.AdjustBridgeDBValue(cmd)
+
+	// start themis config
+	helper.InitThemisConfig("")
+}
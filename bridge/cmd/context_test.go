@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	tendermintLogger "github.com/tendermint/tendermint/libs/log"
+)
+
+// TestBridgeContext_Independent constructs two BridgeContexts in the same
+// process and proves neither their viper state nor their metrics mux is
+// shared, unlike the old package-level rootCmd/metricsServer/viper globals.
+func TestBridgeContext_Independent(t *testing.T) {
+	v1 := viper.New()
+	v2 := viper.New()
+
+	bc1 := NewBridgeContext(v1, tendermintLogger.NewNopLogger())
+	bc2 := NewBridgeContext(v2, tendermintLogger.NewNopLogger())
+
+	cmd1 := bc1.BridgeCommands("test-1")
+	cmd2 := bc2.BridgeCommands("test-2")
+
+	if err := cmd1.PersistentFlags().Set(bridgeDBFlag, "/tmp/bridge-1"); err != nil {
+		t.Fatalf("failed to set flag on cmd1: %v", err)
+	}
+
+	if err := cmd2.PersistentFlags().Set(bridgeDBFlag, "/tmp/bridge-2"); err != nil {
+		t.Fatalf("failed to set flag on cmd2: %v", err)
+	}
+
+	bc1.AdjustBridgeDBValue(cmd1)
+	bc2.AdjustBridgeDBValue(cmd2)
+
+	if got := bc1.Viper.GetString(bridgeDBFlag); got != "/tmp/bridge-1" {
+		t.Errorf("bc1 bridge-db = %q, want /tmp/bridge-1", got)
+	}
+
+	if got := bc2.Viper.GetString(bridgeDBFlag); got != "/tmp/bridge-2" {
+		t.Errorf("bc2 bridge-db = %q, want /tmp/bridge-2", got)
+	}
+
+	if bc1.metricsMux == bc2.metricsMux {
+		t.Error("expected independent BridgeContexts to have distinct metrics muxes")
+	}
+}
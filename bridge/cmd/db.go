@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/attribute"
+	otelmetric "go.opentelemetry.io/otel/metric"
+
+	"github.com/metis-seq/themis/bridge/db"
+)
+
+const (
+	bridgeDBBackendFlag  = "bridge-db-backend"
+	bridgeDBDSNFlag      = "bridge-db-dsn"
+	bridgeDBOptionsFlag  = "bridge-db-options"
+	defaultBridgeBackend = string(db.LevelDBBackend)
+)
+
+// decorateWithDBFlags registers the flags that select and configure the
+// bridge db backend.
+func (bc *BridgeContext) decorateWithDBFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().String(bridgeDBBackendFlag, defaultBridgeBackend, "Bridge db backend: leveldb, pebble, badger, memory, or postgres")
+	cmd.PersistentFlags().String(bridgeDBDSNFlag, "", "Bridge db connection string, used by network backends such as postgres")
+	cmd.PersistentFlags().StringToString(bridgeDBOptionsFlag, nil, "backend-specific tuning options, e.g. max-open-conns=10,cache-size-mb=64")
+}
+
+// dbConfig builds a db.Config for this context's currently resolved
+// bridge db flags. The backend and DSN honor the same flag > env >
+// config file > default precedence as AdjustBridgeDBValue; the options
+// are read from the --bridge-db-options flag alone, not from env vars or
+// the config file.
+func (bc *BridgeContext) dbConfig(cmd *cobra.Command) db.Config {
+	backend := bc.resolveString(cmd, bridgeDBBackendFlag)
+	dsn := bc.resolveString(cmd, bridgeDBDSNFlag)
+	options, _ := cmd.Flags().GetStringToString(bridgeDBOptionsFlag)
+
+	return db.Config{
+		Backend: db.BackendType(backend),
+		Path:    bc.Viper.GetString(bridgeDBFlag),
+		DSN:     dsn,
+		Options: options,
+	}
+}
+
+// openBridgeDB opens the bridge's storage backend through the db
+// factory, using this context's resolved --bridge-db-backend/
+// --bridge-db-dsn/--bridge-db-options flags, and stores the handle on
+// bc.DB. It is called once from BridgeCommands' PersistentPreRunE so the
+// daemon - and the "bridge-db" readiness check registered alongside it -
+// share a single handle instead of each opening their own.
+func (bc *BridgeContext) openBridgeDB(cmd *cobra.Command) error {
+	backend, err := db.Open(context.Background(), bc.dbConfig(cmd))
+	if err != nil {
+		return fmt.Errorf("failed to open bridge db: %w", err)
+	}
+
+	bc.DB = backend
+
+	return nil
+}
+
+// dbCommands returns the `db` subcommand tree (currently just `migrate`)
+// mounted on the bridge root command. It carries its own PersistentPreRunE
+// rather than inheriting the root's: the root hook starts a metrics
+// server, initializes tracing, and calls openBridgeDB, which takes an
+// exclusive file lock on the on-disk backends (leveldb, pebble, badger).
+// Since PostRunE is not persistent, it never fires for a subcommand
+// invocation to release that lock, so `db migrate --from leveldb` would
+// otherwise deadlock trying to open the same backend a second time inside
+// migrateDB. db subcommands are one-shot CLI tools, not daemons, so they
+// only need flag/env/config-file resolution, not the daemon bootstrap.
+func (bc *BridgeContext) dbCommands() *cobra.Command {
+	dbCmd := &cobra.Command{
+		Use:   "db",
+		Short: "Inspect or migrate the bridge db",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			bc.initTendermintViperConfig(cmd)
+			return nil
+		},
+	}
+
+	dbCmd.AddCommand(bc.dbMigrateCommand())
+
+	return dbCmd
+}
+
+func (bc *BridgeContext) dbMigrateCommand() *cobra.Command {
+	var from, to, dsn string
+
+	migrateCmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Copy every key in the bridge db from one backend to another",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bc.Viper.Set(bridgeDBDSNFlag, bc.resolveString(cmd, bridgeDBDSNFlag))
+
+			return bc.migrateDB(cmd.Context(), from, to, dsn)
+		},
+	}
+
+	migrateCmd.Flags().StringVar(&from, "from", defaultBridgeBackend, "source backend: leveldb, pebble, badger, memory, or postgres")
+	migrateCmd.Flags().StringVar(&to, "to", "", "destination backend: leveldb, pebble, badger, memory, or postgres")
+	migrateCmd.Flags().StringVar(&dsn, "to-dsn", "", "connection string for the destination backend, if it needs one")
+
+	return migrateCmd
+}
+
+// migrateDB copies every key from the source backend into the
+// destination backend in a single pass. The source is opened against the
+// bridge-db path/DSN already resolved onto this context's viper; the
+// destination at that path with a "-<to>" suffix, using toDSN.
+func (bc *BridgeContext) migrateDB(ctx context.Context, from, to, toDSN string) error {
+	ctx, span := bc.Providers().Tracer("bridge/cmd").Start(ctx, "db.migrate")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("bridge.db.migrate.from", from),
+		attribute.String("bridge.db.migrate.to", to),
+	)
+
+	migratedKeys, err := bc.Providers().Meter("bridge/cmd").Int64Counter("bridge_db_migrated_keys_total")
+	if err != nil {
+		return fmt.Errorf("failed to create migrated-keys counter: %w", err)
+	}
+
+	if to == "" {
+		return fmt.Errorf("--to is required")
+	}
+
+	source, err := db.Open(ctx, db.Config{
+		Backend: db.BackendType(from),
+		Path:    bc.Viper.GetString(bridgeDBFlag),
+		DSN:     bc.Viper.GetString(bridgeDBDSNFlag),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to open source backend %q: %w", from, err)
+	}
+	defer source.Close()
+
+	dest, err := db.Open(ctx, db.Config{
+		Backend: db.BackendType(to),
+		Path:    strings.TrimSuffix(bc.Viper.GetString(bridgeDBFlag), "/") + "-" + to,
+		DSN:     toDSN,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to open destination backend %q: %w", to, err)
+	}
+	defer dest.Close()
+
+	batch := dest.Batch()
+	var migrated int64
+
+	if err := source.Iterate(ctx, nil, func(key, value []byte) error {
+		batch.Put(key, value)
+		migrated++
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to read source backend: %w", err)
+	}
+
+	if err := batch.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to write destination backend: %w", err)
+	}
+
+	migratedKeys.Add(ctx, migrated, otelmetric.WithAttributes(
+		attribute.String("from", from),
+		attribute.String("to", to),
+	))
+	span.SetAttributes(attribute.Int64("bridge.db.migrate.keys", migrated))
+
+	bc.Logger.Info("bridge db migration complete", "from", from, "to", to, "keys", migrated)
+
+	return nil
+}
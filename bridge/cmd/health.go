@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/metis-seq/themis/bridge/health"
+	"github.com/metis-seq/themis/helper"
+)
+
+const (
+	healthServerAddrFlag = "health-server-addr"
+	maxBlockLagFlag      = "max-block-lag"
+
+	defaultMaxBlockLag = 50
+
+	tendermintRPCTimeout = 2 * time.Second
+)
+
+var (
+	dbHealthCheckKey   = []byte("__bridge_health_check__")
+	dbHealthCheckValue = []byte("ok")
+)
+
+// decorateWithHealthFlags registers the flags controlling the
+// /healthz, /readyz, and /livez probe endpoints.
+func (bc *BridgeContext) decorateWithHealthFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().String(healthServerAddrFlag, "", "dedicated health probe listen addr; if empty, probes are served on the metrics server")
+	cmd.PersistentFlags().Int(maxBlockLagFlag, defaultMaxBlockLag, "max blocks the bridge's last-processed block may lag behind head before /readyz fails")
+}
+
+// initHealth mounts /healthz, /readyz, and /livez on this context's
+// metrics mux, or on a dedicated HTTP server when --health-server-addr is
+// set. It also starts a goroutine that marks the registry draining as
+// soon as SIGTERM arrives, so /readyz starts failing immediately for load
+// balancers while /livez keeps reporting healthy until in-flight work
+// completes and the process actually exits.
+func (bc *BridgeContext) initHealth(cmd *cobra.Command) {
+	mux := bc.metricsMux
+
+	if healthAddr, _ := cmd.Flags().GetString(healthServerAddrFlag); healthAddr != "" {
+		mux = http.NewServeMux()
+
+		bc.HealthServer = &http.Server{Addr: healthAddr, Handler: mux}
+
+		go func() {
+			if err := bc.HealthServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				bc.Logger.Error("failed to start health server", "error", err)
+			}
+		}()
+	}
+
+	mux.HandleFunc("/livez", bc.Health.LivezHandler())
+	mux.HandleFunc("/readyz", bc.Health.ReadyzHandler())
+	mux.HandleFunc("/healthz", bc.Health.HealthzHandler())
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGTERM)
+
+	go func() {
+		<-sigCh
+		bc.Logger.Info("received SIGTERM, draining: /readyz will report unhealthy until shutdown completes")
+		bc.Health.Drain()
+	}()
+}
+
+// registerHealthCheckers registers the readiness checks the request asks
+// for: Tendermint RPC reachable, the bridge db open, and the bridge's
+// last-processed block within --max-block-lag of Tendermint's head.
+func (bc *BridgeContext) registerHealthCheckers(cmd *cobra.Command) {
+	tendermintNode := bc.Viper.GetString(helper.TendermintNodeFlag)
+	maxBlockLag, _ := cmd.Flags().GetInt(maxBlockLagFlag)
+
+	client := &http.Client{Timeout: tendermintRPCTimeout}
+
+	bc.Health.Register("tendermint-rpc", health.CheckerFunc(func(ctx context.Context) error {
+		_, err := fetchTendermintHeight(ctx, client, tendermintNode)
+		return err
+	}))
+
+	bc.Health.Register("bridge-db", health.CheckerFunc(func(ctx context.Context) error {
+		if bc.DB == nil {
+			return fmt.Errorf("bridge db not open")
+		}
+
+		return bc.DB.Put(ctx, dbHealthCheckKey, dbHealthCheckValue)
+	}))
+
+	bc.Health.Register("block-lag", health.CheckerFunc(func(ctx context.Context) error {
+		lastProcessed := bc.LastProcessedHeight.Load()
+		if lastProcessed == 0 {
+			// no processor has reported progress yet
+			return nil
+		}
+
+		head, err := fetchTendermintHeight(ctx, client, tendermintNode)
+		if err != nil {
+			return fmt.Errorf("failed to fetch tendermint head height: %w", err)
+		}
+
+		if lag := head - lastProcessed; lag > int64(maxBlockLag) {
+			return fmt.Errorf("bridge is %d blocks behind head, want <= %d", lag, maxBlockLag)
+		}
+
+		return nil
+	}))
+}
+
+// tendermintStatusResponse is the subset of Tendermint's /status RPC
+// response the block-lag check needs.
+type tendermintStatusResponse struct {
+	Result struct {
+		SyncInfo struct {
+			LatestBlockHeight string `json:"latest_block_height"`
+		} `json:"sync_info"`
+	} `json:"result"`
+}
+
+// fetchTendermintHeight queries nodeAddr's Tendermint RPC /status
+// endpoint and returns its latest block height.
+func fetchTendermintHeight(ctx context.Context, client *http.Client, nodeAddr string) (int64, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(nodeAddr, "/")+"/status", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("tendermint rpc returned status %d", resp.StatusCode)
+	}
+
+	var status tendermintStatusResponse
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return 0, fmt.Errorf("failed to decode tendermint status: %w", err)
+	}
+
+	height, err := strconv.ParseInt(status.Result.SyncInfo.LatestBlockHeight, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse latest_block_height %q: %w", status.Result.SyncInfo.LatestBlockHeight, err)
+	}
+
+	return height, nil
+}
@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/cobra"
+	tendermintLogger "github.com/tendermint/tendermint/libs/log"
+
+	bridgedb "github.com/metis-seq/themis/bridge/db"
+	"github.com/metis-seq/themis/helper"
+)
+
+func newTendermintStatusServer(t *testing.T, height string) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"result":{"sync_info":{"latest_block_height":"` + height + `"}}}`))
+	}))
+}
+
+func commandWithHealthFlags(t *testing.T, tendermintNode string) *cobra.Command {
+	t.Helper()
+
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().Int(maxBlockLagFlag, 10, "")
+
+	return cmd
+}
+
+func TestRegisterHealthCheckers_AllHealthy(t *testing.T) {
+	server := newTendermintStatusServer(t, "100")
+	defer server.Close()
+
+	bc := NewBridgeContext(nil, tendermintLogger.NewNopLogger())
+	bc.Viper.Set(helper.TendermintNodeFlag, server.URL)
+
+	backend, err := bridgedb.Open(context.Background(), bridgedb.Config{Backend: bridgedb.MemDBBackend})
+	if err != nil {
+		t.Fatalf("failed to open memory backend: %v", err)
+	}
+	bc.DB = backend
+
+	bc.LastProcessedHeight.Store(95)
+
+	bc.registerHealthCheckers(commandWithHealthFlags(t, server.URL))
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	rec := httptest.NewRecorder()
+	bc.Health.ReadyzHandler()(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("readyz status = %d, want %d, body = %s", rec.Code, http.StatusOK, rec.Body.String())
+	}
+}
+
+func TestRegisterHealthCheckers_BlockLagExceeded(t *testing.T) {
+	server := newTendermintStatusServer(t, "1000")
+	defer server.Close()
+
+	bc := NewBridgeContext(nil, tendermintLogger.NewNopLogger())
+	bc.Viper.Set(helper.TendermintNodeFlag, server.URL)
+
+	backend, err := bridgedb.Open(context.Background(), bridgedb.Config{Backend: bridgedb.MemDBBackend})
+	if err != nil {
+		t.Fatalf("failed to open memory backend: %v", err)
+	}
+	bc.DB = backend
+
+	bc.LastProcessedHeight.Store(100)
+
+	bc.registerHealthCheckers(commandWithHealthFlags(t, server.URL))
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	rec := httptest.NewRecorder()
+	bc.Health.ReadyzHandler()(rec, req)
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("readyz status = %d, want %d once block lag exceeds --max-block-lag, body = %s", rec.Code, http.StatusServiceUnavailable, rec.Body.String())
+	}
+}
+
+func TestRegisterHealthCheckers_DBNotOpen(t *testing.T) {
+	server := newTendermintStatusServer(t, "100")
+	defer server.Close()
+
+	bc := NewBridgeContext(nil, tendermintLogger.NewNopLogger())
+	bc.Viper.Set(helper.TendermintNodeFlag, server.URL)
+
+	bc.registerHealthCheckers(commandWithHealthFlags(t, server.URL))
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	rec := httptest.NewRecorder()
+	bc.Health.ReadyzHandler()(rec, req)
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("readyz status = %d, want %d when bridge db was never opened, body = %s", rec.Code, http.StatusServiceUnavailable, rec.Body.String())
+	}
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	otelprom "go.opentelemetry.io/otel/exporters/prometheus"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+
+	"github.com/metis-seq/themis/helper"
+)
+
+const (
+	otlpEndpointFlag       = "otlp-endpoint"
+	otlpProtocolFlag       = "otlp-protocol"
+	otlpHeadersFlag        = "otlp-headers"
+	traceSamplingRatioFlag = "trace-sampling-ratio"
+	serviceNameFlag        = "service-name"
+
+	otlpProtocolGRPC = "grpc"
+	otlpProtocolHTTP = "http/protobuf"
+
+	defaultTraceSamplingRatio = 1.0
+	defaultServiceName        = "themis-bridge"
+)
+
+// decorateWithTracingFlags registers the OTLP tracing/metrics flags
+// alongside the other bridge root flags.
+func (bc *BridgeContext) decorateWithTracingFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().String(otlpEndpointFlag, "", "OTLP collector endpoint (host:port); tracing/metrics export is disabled when empty")
+	cmd.PersistentFlags().String(otlpProtocolFlag, otlpProtocolGRPC, "OTLP exporter protocol: grpc or http/protobuf")
+	cmd.PersistentFlags().StringToString(otlpHeadersFlag, nil, "extra headers sent with every OTLP export request")
+	cmd.PersistentFlags().Float64(traceSamplingRatioFlag, defaultTraceSamplingRatio, "fraction of traces to sample, between 0 and 1")
+	cmd.PersistentFlags().String(serviceNameFlag, defaultServiceName, "service.name reported to tracing/metrics backends")
+}
+
+// initTracing installs this context's TracerProvider and MeterProvider.
+// The TracerProvider exports spans to the OTLP collector named by
+// --otlp-endpoint. The MeterProvider bridges into the existing Prometheus
+// registry via the OTel Prometheus exporter, so /metrics keeps serving
+// OTel-recorded metrics alongside anything registered directly against
+// promhttp.Handler(); metrics are not pushed to the OTLP collector. If
+// --otlp-endpoint is empty, both are skipped and
+// bc.TracerProvider/bc.MeterProvider stay nil.
+//
+// Deliberately not installed: otel.SetTracerProvider/otel.SetMeterProvider.
+// Those setters are package-level globals in go.opentelemetry.io/otel, so
+// calling them here would let a second BridgeContext in the same process
+// silently clobber the first one's provider - exactly the kind of shared
+// state BridgeContext exists to avoid. Callers reach this context's
+// providers through bc.Providers() instead.
+func (bc *BridgeContext) initTracing(cmd *cobra.Command) error {
+	endpoint, _ := cmd.Flags().GetString(otlpEndpointFlag)
+	if endpoint == "" {
+		return nil
+	}
+
+	protocol, _ := cmd.Flags().GetString(otlpProtocolFlag)
+	headers, _ := cmd.Flags().GetStringToString(otlpHeadersFlag)
+	samplingRatio, _ := cmd.Flags().GetFloat64(traceSamplingRatioFlag)
+	serviceName, _ := cmd.Flags().GetString(serviceNameFlag)
+
+	res := resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+	)
+
+	traceExporter, err := newOTLPTraceExporter(context.Background(), protocol, endpoint, headers)
+	if err != nil {
+		return fmt.Errorf("failed to build OTLP trace exporter: %w", err)
+	}
+
+	bc.TracerProvider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(traceExporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(samplingRatio))),
+	)
+
+	promExporter, err := otelprom.New()
+	if err != nil {
+		return fmt.Errorf("failed to build OTel Prometheus exporter: %w", err)
+	}
+
+	bc.MeterProvider = sdkmetric.NewMeterProvider(
+		sdkmetric.WithResource(res),
+		sdkmetric.WithReader(promExporter),
+	)
+
+	return nil
+}
+
+// Providers returns a helper.Providers view onto this context's tracer
+// and meter providers. Code that wants to create spans or counters
+// against this specific BridgeContext - rather than some other context
+// that happens to share the process - should call bc.Providers().Tracer/
+// .Meter instead of the OTel package-level otel.Tracer/otel.Meter.
+func (bc *BridgeContext) Providers() helper.Providers {
+	// copy only non-nil providers: a nil *sdktrace.TracerProvider stored in
+	// the interface field would not compare equal to nil, and helper.Providers
+	// would then call into it instead of falling back to a no-op.
+	var p helper.Providers
+
+	if bc.TracerProvider != nil {
+		p.TracerProvider = bc.TracerProvider
+	}
+
+	if bc.MeterProvider != nil {
+		p.MeterProvider = bc.MeterProvider
+	}
+
+	return p
+}
+
+// newOTLPTraceExporter builds the trace exporter matching the requested
+// wire protocol.
+func newOTLPTraceExporter(ctx context.Context, protocol, endpoint string, headers map[string]string) (*otlptrace.Exporter, error) {
+	switch protocol {
+	case "", otlpProtocolGRPC:
+		return otlptracegrpc.New(ctx,
+			otlptracegrpc.WithEndpoint(endpoint),
+			otlptracegrpc.WithHeaders(headers),
+			otlptracegrpc.WithInsecure(),
+		)
+	case otlpProtocolHTTP:
+		return otlptracehttp.New(ctx,
+			otlptracehttp.WithEndpoint(endpoint),
+			otlptracehttp.WithHeaders(headers),
+			otlptracehttp.WithInsecure(),
+		)
+	default:
+		return nil, fmt.Errorf("unsupported %s %q, want one of %s", otlpProtocolFlag, protocol, strings.Join([]string{otlpProtocolGRPC, otlpProtocolHTTP}, ", "))
+	}
+}
+
+// shutdownTracing flushes and stops the TracerProvider and MeterProvider
+// installed by initTracing, if any were installed.
+func (bc *BridgeContext) shutdownTracing(ctx context.Context) error {
+	if bc.TracerProvider != nil {
+		if err := bc.TracerProvider.Shutdown(ctx); err != nil {
+			return fmt.Errorf("failed to shut down tracer provider: %w", err)
+		}
+	}
+
+	if bc.MeterProvider != nil {
+		if err := bc.MeterProvider.Shutdown(ctx); err != nil {
+			return fmt.Errorf("failed to shut down meter provider: %w", err)
+		}
+	}
+
+	return nil
+}
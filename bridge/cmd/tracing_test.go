@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"testing"
+
+	tendermintLogger "github.com/tendermint/tendermint/libs/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// TestBridgeContext_ProvidersAreIsolated proves that two BridgeContexts in
+// the same process keep independent tracer providers: Providers() returns
+// bc1's own TracerProvider, and bc2, which never had one set, sees none.
+func TestBridgeContext_ProvidersAreIsolated(t *testing.T) {
+	bc1 := NewBridgeContext(nil, tendermintLogger.NewNopLogger())
+	bc2 := NewBridgeContext(nil, tendermintLogger.NewNopLogger())
+
+	bc1.TracerProvider = sdktrace.NewTracerProvider()
+
+	if bc1.Providers().TracerProvider != bc1.TracerProvider {
+		t.Error("bc1.Providers() did not return bc1's own TracerProvider")
+	}
+
+	if bc2.Providers().TracerProvider != nil {
+		t.Error("bc2.Providers() picked up bc1's TracerProvider; providers are not isolated per BridgeContext")
+	}
+}
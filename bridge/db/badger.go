@@ -0,0 +1,109 @@
+package db
+
+import (
+	"context"
+
+	"github.com/dgraph-io/badger/v4"
+)
+
+func init() {
+	RegisterBackend(BadgerBackend, openBadger)
+}
+
+type badgerBackend struct {
+	db *badger.DB
+}
+
+func openBadger(_ context.Context, cfg Config) (Backend, error) {
+	bdb, err := badger.Open(badger.DefaultOptions(cfg.Path).WithLogger(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	return &badgerBackend{db: bdb}, nil
+}
+
+func (b *badgerBackend) Get(_ context.Context, key []byte) ([]byte, error) {
+	var value []byte
+
+	err := b.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get(key)
+		if err != nil {
+			return err
+		}
+
+		return item.Value(func(v []byte) error {
+			value = append([]byte{}, v...)
+			return nil
+		})
+	})
+
+	return value, err
+}
+
+func (b *badgerBackend) Put(_ context.Context, key, value []byte) error {
+	return b.db.Update(func(txn *badger.Txn) error {
+		return txn.Set(key, value)
+	})
+}
+
+func (b *badgerBackend) Delete(_ context.Context, key []byte) error {
+	return b.db.Update(func(txn *badger.Txn) error {
+		return txn.Delete(key)
+	})
+}
+
+func (b *badgerBackend) Iterate(_ context.Context, prefix []byte, fn func(key, value []byte) error) error {
+	return b.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = prefix
+
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Seek(prefix); it.ValidForPrefix(prefix); it.Next() {
+			item := it.Item()
+
+			if err := item.Value(func(v []byte) error {
+				return fn(item.KeyCopy(nil), append([]byte{}, v...))
+			}); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (b *badgerBackend) Batch() Batch {
+	return &badgerBatch{wb: b.db.NewWriteBatch()}
+}
+
+func (b *badgerBackend) Close() error {
+	return b.db.Close()
+}
+
+type badgerBatch struct {
+	wb  *badger.WriteBatch
+	err error
+}
+
+func (b *badgerBatch) Put(key, value []byte) {
+	if err := b.wb.Set(key, value); err != nil {
+		b.err = err
+	}
+}
+
+func (b *badgerBatch) Delete(key []byte) {
+	if err := b.wb.Delete(key); err != nil {
+		b.err = err
+	}
+}
+
+func (b *badgerBatch) Commit(_ context.Context) error {
+	if b.err != nil {
+		return b.err
+	}
+
+	return b.wb.Flush()
+}
@@ -0,0 +1,77 @@
+package db
+
+import (
+	"context"
+
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+func init() {
+	RegisterBackend(LevelDBBackend, openLevelDB)
+}
+
+// levelDBBackend is the default backend, matching the behavior the
+// bridge processors relied on before bridge/db existed.
+type levelDBBackend struct {
+	db *leveldb.DB
+}
+
+func openLevelDB(_ context.Context, cfg Config) (Backend, error) {
+	ldb, err := leveldb.OpenFile(cfg.Path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &levelDBBackend{db: ldb}, nil
+}
+
+func (b *levelDBBackend) Get(_ context.Context, key []byte) ([]byte, error) {
+	return b.db.Get(key, nil)
+}
+
+func (b *levelDBBackend) Put(_ context.Context, key, value []byte) error {
+	return b.db.Put(key, value, nil)
+}
+
+func (b *levelDBBackend) Delete(_ context.Context, key []byte) error {
+	return b.db.Delete(key, nil)
+}
+
+func (b *levelDBBackend) Iterate(_ context.Context, prefix []byte, fn func(key, value []byte) error) error {
+	iter := b.db.NewIterator(util.BytesPrefix(prefix), nil)
+	defer iter.Release()
+
+	for iter.Next() {
+		if err := fn(iter.Key(), iter.Value()); err != nil {
+			return err
+		}
+	}
+
+	return iter.Error()
+}
+
+func (b *levelDBBackend) Batch() Batch {
+	return &levelDBBatch{db: b.db, batch: new(leveldb.Batch)}
+}
+
+func (b *levelDBBackend) Close() error {
+	return b.db.Close()
+}
+
+type levelDBBatch struct {
+	db    *leveldb.DB
+	batch *leveldb.Batch
+}
+
+func (b *levelDBBatch) Put(key, value []byte) {
+	b.batch.Put(key, value)
+}
+
+func (b *levelDBBatch) Delete(key []byte) {
+	b.batch.Delete(key)
+}
+
+func (b *levelDBBatch) Commit(_ context.Context) error {
+	return b.db.Write(b.batch, nil)
+}
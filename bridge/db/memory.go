@@ -0,0 +1,125 @@
+package db
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"sync"
+)
+
+func init() {
+	RegisterBackend(MemDBBackend, openMemDB)
+}
+
+// memDBBackend is an in-process, non-persistent backend intended for
+// tests and single-node throwaway environments.
+type memDBBackend struct {
+	mu   sync.RWMutex
+	data map[string][]byte
+}
+
+func openMemDB(_ context.Context, _ Config) (Backend, error) {
+	return &memDBBackend{data: make(map[string][]byte)}, nil
+}
+
+func (b *memDBBackend) Get(_ context.Context, key []byte) ([]byte, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	value, ok := b.data[string(key)]
+	if !ok {
+		return nil, nil
+	}
+
+	return append([]byte{}, value...), nil
+}
+
+func (b *memDBBackend) Put(_ context.Context, key, value []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.data[string(key)] = append([]byte{}, value...)
+
+	return nil
+}
+
+func (b *memDBBackend) Delete(_ context.Context, key []byte) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.data, string(key))
+
+	return nil
+}
+
+func (b *memDBBackend) Iterate(_ context.Context, prefix []byte, fn func(key, value []byte) error) error {
+	b.mu.RLock()
+	keys := make([]string, 0, len(b.data))
+
+	for k := range b.data {
+		if bytes.HasPrefix([]byte(k), prefix) {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+
+	values := make(map[string][]byte, len(keys))
+	for _, k := range keys {
+		values[k] = b.data[k]
+	}
+	b.mu.RUnlock()
+
+	for _, k := range keys {
+		if err := fn([]byte(k), values[k]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *memDBBackend) Batch() Batch {
+	return &memDBBatch{db: b}
+}
+
+func (b *memDBBackend) Close() error {
+	return nil
+}
+
+type memDBOp struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+type memDBBatch struct {
+	db  *memDBBackend
+	ops []memDBOp
+}
+
+func (b *memDBBatch) Put(key, value []byte) {
+	b.ops = append(b.ops, memDBOp{key: key, value: value})
+}
+
+func (b *memDBBatch) Delete(key []byte) {
+	b.ops = append(b.ops, memDBOp{key: key, delete: true})
+}
+
+func (b *memDBBatch) Commit(_ context.Context) error {
+	// hold the write lock across every op so readers never see a
+	// partially applied batch
+	b.db.mu.Lock()
+	defer b.db.mu.Unlock()
+
+	for _, op := range b.ops {
+		if op.delete {
+			delete(b.db.data, string(op.key))
+			continue
+		}
+
+		b.db.data[string(op.key)] = append([]byte{}, op.value...)
+	}
+
+	return nil
+}
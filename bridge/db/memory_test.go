@@ -0,0 +1,103 @@
+package db
+
+import (
+	"context"
+	"testing"
+)
+
+func TestMemDBBackend_GetPutDelete(t *testing.T) {
+	backend, err := Open(context.Background(), Config{Backend: MemDBBackend})
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer backend.Close()
+
+	ctx := context.Background()
+
+	if err := backend.Put(ctx, []byte("a"), []byte("1")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	value, err := backend.Get(ctx, []byte("a"))
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if string(value) != "1" {
+		t.Fatalf("Get() = %q, want %q", value, "1")
+	}
+
+	if err := backend.Delete(ctx, []byte("a")); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+
+	value, err = backend.Get(ctx, []byte("a"))
+	if err != nil {
+		t.Fatalf("Get() after delete error = %v", err)
+	}
+
+	if value != nil {
+		t.Fatalf("Get() after delete = %q, want nil", value)
+	}
+}
+
+func TestMemDBBackend_IterateByPrefix(t *testing.T) {
+	backend, err := Open(context.Background(), Config{Backend: MemDBBackend})
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer backend.Close()
+
+	ctx := context.Background()
+
+	if err := backend.Put(ctx, []byte("span/1"), []byte("a")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if err := backend.Put(ctx, []byte("span/2"), []byte("b")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+	if err := backend.Put(ctx, []byte("checkpoint/1"), []byte("c")); err != nil {
+		t.Fatalf("Put() error = %v", err)
+	}
+
+	var got []string
+
+	err = backend.Iterate(ctx, []byte("span/"), func(key, value []byte) error {
+		got = append(got, string(key))
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Iterate() error = %v", err)
+	}
+
+	if len(got) != 2 {
+		t.Fatalf("Iterate() visited %d keys, want 2: %v", len(got), got)
+	}
+}
+
+func TestMemDBBackend_Batch(t *testing.T) {
+	backend, err := Open(context.Background(), Config{Backend: MemDBBackend})
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer backend.Close()
+
+	ctx := context.Background()
+
+	batch := backend.Batch()
+	batch.Put([]byte("a"), []byte("1"))
+	batch.Put([]byte("b"), []byte("2"))
+
+	if err := batch.Commit(ctx); err != nil {
+		t.Fatalf("Commit() error = %v", err)
+	}
+
+	value, err := backend.Get(ctx, []byte("b"))
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	if string(value) != "2" {
+		t.Fatalf("Get() = %q, want %q", value, "2")
+	}
+}
@@ -0,0 +1,105 @@
+package db
+
+import (
+	"context"
+
+	"github.com/cockroachdb/pebble"
+)
+
+func init() {
+	RegisterBackend(PebbleBackend, openPebble)
+}
+
+type pebbleBackend struct {
+	db *pebble.DB
+}
+
+func openPebble(_ context.Context, cfg Config) (Backend, error) {
+	pdb, err := pebble.Open(cfg.Path, &pebble.Options{})
+	if err != nil {
+		return nil, err
+	}
+
+	return &pebbleBackend{db: pdb}, nil
+}
+
+func (b *pebbleBackend) Get(_ context.Context, key []byte) ([]byte, error) {
+	value, closer, err := b.db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	defer closer.Close()
+
+	out := make([]byte, len(value))
+	copy(out, value)
+
+	return out, nil
+}
+
+func (b *pebbleBackend) Put(_ context.Context, key, value []byte) error {
+	return b.db.Set(key, value, pebble.Sync)
+}
+
+func (b *pebbleBackend) Delete(_ context.Context, key []byte) error {
+	return b.db.Delete(key, pebble.Sync)
+}
+
+func (b *pebbleBackend) Iterate(_ context.Context, prefix []byte, fn func(key, value []byte) error) error {
+	iter, err := b.db.NewIter(&pebble.IterOptions{
+		LowerBound: prefix,
+		UpperBound: prefixUpperBound(prefix),
+	})
+	if err != nil {
+		return err
+	}
+	defer iter.Close()
+
+	for iter.First(); iter.Valid(); iter.Next() {
+		if err := fn(iter.Key(), iter.Value()); err != nil {
+			return err
+		}
+	}
+
+	return iter.Error()
+}
+
+func (b *pebbleBackend) Batch() Batch {
+	return &pebbleBatch{db: b.db, batch: b.db.NewBatch()}
+}
+
+func (b *pebbleBackend) Close() error {
+	return b.db.Close()
+}
+
+type pebbleBatch struct {
+	db    *pebble.DB
+	batch *pebble.Batch
+}
+
+func (b *pebbleBatch) Put(key, value []byte) {
+	_ = b.batch.Set(key, value, nil)
+}
+
+func (b *pebbleBatch) Delete(key []byte) {
+	_ = b.batch.Delete(key, nil)
+}
+
+func (b *pebbleBatch) Commit(_ context.Context) error {
+	return b.db.Apply(b.batch, pebble.Sync)
+}
+
+// prefixUpperBound returns the smallest key greater than every key with
+// the given prefix, for use as a pebble iterator's exclusive upper bound.
+func prefixUpperBound(prefix []byte) []byte {
+	upper := make([]byte, len(prefix))
+	copy(upper, prefix)
+
+	for i := len(upper) - 1; i >= 0; i-- {
+		upper[i]++
+		if upper[i] != 0 {
+			return upper[:i+1]
+		}
+	}
+
+	return nil
+}
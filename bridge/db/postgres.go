@@ -0,0 +1,167 @@
+package db
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func init() {
+	RegisterBackend(PostgresBackend, openPostgres)
+}
+
+// postgresBackend stores entries in a single key/value table, shared
+// across bridge instances in a cross-region deployment. Neither this
+// backend nor `themis-bridge db migrate` creates the table; it must exist
+// before first use:
+//
+//	CREATE TABLE bridge_kv (key BYTEA PRIMARY KEY, value BYTEA NOT NULL);
+type postgresBackend struct {
+	pool *pgxpool.Pool
+}
+
+// openPostgres honors the "max-open-conns" key of Config.Options (see
+// --bridge-db-options), the only postgres-specific tuning knob this
+// backend currently reads.
+func openPostgres(ctx context.Context, cfg Config) (Backend, error) {
+	poolConfig, err := pgxpool.ParseConfig(cfg.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse postgres dsn: %w", err)
+	}
+
+	if v, ok := cfg.Options["max-open-conns"]; ok {
+		maxConns, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid max-open-conns option %q: %w", v, err)
+		}
+
+		poolConfig.MaxConns = int32(maxConns)
+	}
+
+	pool, err := pgxpool.NewWithConfig(ctx, poolConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, fmt.Errorf("failed to ping postgres: %w", err)
+	}
+
+	return &postgresBackend{pool: pool}, nil
+}
+
+func (b *postgresBackend) Get(ctx context.Context, key []byte) ([]byte, error) {
+	var value []byte
+
+	err := b.pool.QueryRow(ctx, "SELECT value FROM bridge_kv WHERE key = $1", key).Scan(&value)
+
+	return value, err
+}
+
+func (b *postgresBackend) Put(ctx context.Context, key, value []byte) error {
+	_, err := b.pool.Exec(ctx,
+		"INSERT INTO bridge_kv (key, value) VALUES ($1, $2) ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value",
+		key, value)
+
+	return err
+}
+
+func (b *postgresBackend) Delete(ctx context.Context, key []byte) error {
+	_, err := b.pool.Exec(ctx, "DELETE FROM bridge_kv WHERE key = $1", key)
+
+	return err
+}
+
+func (b *postgresBackend) Iterate(ctx context.Context, prefix []byte, fn func(key, value []byte) error) error {
+	// key >= NULL evaluates to NULL in postgres, which would make the
+	// WHERE clause below silently match nothing for a full-table scan, so
+	// a nil/empty prefix - "match everything", same as every other
+	// backend - needs its own query with no lower bound at all.
+	var rows pgx.Rows
+	var err error
+
+	if len(prefix) == 0 {
+		rows, err = b.pool.Query(ctx, "SELECT key, value FROM bridge_kv ORDER BY key")
+	} else {
+		rows, err = b.pool.Query(ctx, "SELECT key, value FROM bridge_kv WHERE key >= $1 ORDER BY key", prefix)
+	}
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var key, value []byte
+		if err := rows.Scan(&key, &value); err != nil {
+			return err
+		}
+
+		if len(prefix) > 0 && (len(key) < len(prefix) || string(key[:len(prefix)]) != string(prefix)) {
+			break
+		}
+
+		if err := fn(key, value); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (b *postgresBackend) Batch() Batch {
+	return &postgresBatch{backend: b}
+}
+
+func (b *postgresBackend) Close() error {
+	b.pool.Close()
+	return nil
+}
+
+type postgresOp struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+// postgresBatch commits every accumulated op inside a single transaction.
+type postgresBatch struct {
+	backend *postgresBackend
+	ops     []postgresOp
+}
+
+func (b *postgresBatch) Put(key, value []byte) {
+	b.ops = append(b.ops, postgresOp{key: key, value: value})
+}
+
+func (b *postgresBatch) Delete(key []byte) {
+	b.ops = append(b.ops, postgresOp{key: key, delete: true})
+}
+
+func (b *postgresBatch) Commit(ctx context.Context) error {
+	tx, err := b.backend.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx) //nolint:errcheck
+
+	for _, op := range b.ops {
+		if op.delete {
+			if _, err := tx.Exec(ctx, "DELETE FROM bridge_kv WHERE key = $1", op.key); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, err := tx.Exec(ctx,
+			"INSERT INTO bridge_kv (key, value) VALUES ($1, $2) ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value",
+			op.key, op.value); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,174 @@
+// Package health implements the liveness/readiness probe surface the
+// bridge daemon exposes for Kubernetes/Nomad deployments. Bridge
+// subsystems (rootchain listener, span processor, checkpoint processor,
+// sqlite writer) register a Checker with a Registry at startup; the
+// Registry's /readyz and /healthz handlers evaluate every registered
+// Checker on each request and report the aggregate result as JSON, while
+// /livez runs no Checkers.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Checker reports whether a bridge subsystem is currently healthy. An
+// error return means the check failed; the error's message is surfaced
+// verbatim in the probe response.
+type Checker interface {
+	Check(ctx context.Context) error
+}
+
+// CheckerFunc adapts a plain function to a Checker.
+type CheckerFunc func(ctx context.Context) error
+
+func (f CheckerFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// checkResult is the outcome of running a single named Checker.
+type checkResult struct {
+	Status    string `json:"status"`
+	LatencyMS int64  `json:"latencyMs"`
+	Error     string `json:"error,omitempty"`
+}
+
+// report is the JSON body served by /healthz, /readyz, and /livez.
+type report struct {
+	Status string                 `json:"status"`
+	Checks map[string]checkResult `json:"checks,omitempty"`
+}
+
+const (
+	statusOK  = "ok"
+	statusBad = "unhealthy"
+)
+
+// Registry tracks named readiness Checkers and the process's overall
+// liveness/readiness state. The zero value is not usable; construct one
+// with NewRegistry.
+type Registry struct {
+	mu       sync.RWMutex
+	checkers map[string]Checker
+
+	// draining is set once SIGTERM is received: /readyz starts failing
+	// immediately so load balancers stop sending new traffic, while
+	// /livez keeps reporting healthy until in-flight work finishes and
+	// the process actually exits.
+	draining bool
+}
+
+// NewRegistry returns an empty, ready-to-use Registry.
+func NewRegistry() *Registry {
+	return &Registry{checkers: make(map[string]Checker)}
+}
+
+// Register adds a readiness Checker under name, overwriting any Checker
+// previously registered under the same name.
+func (r *Registry) Register(name string, checker Checker) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.checkers[name] = checker
+}
+
+// Drain marks the registry as draining: /readyz will report unhealthy
+// from this point on, even though every individual Checker may still
+// pass. Call this as soon as a shutdown signal is received.
+func (r *Registry) Drain() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.draining = true
+}
+
+func (r *Registry) runChecks(ctx context.Context) (map[string]checkResult, bool) {
+	r.mu.RLock()
+	checkers := make(map[string]Checker, len(r.checkers))
+	for name, checker := range r.checkers {
+		checkers[name] = checker
+	}
+	r.mu.RUnlock()
+
+	results := make(map[string]checkResult, len(checkers))
+	healthy := true
+
+	for name, checker := range checkers {
+		start := time.Now()
+		err := checker.Check(ctx)
+		latency := time.Since(start)
+
+		result := checkResult{Status: statusOK, LatencyMS: latency.Milliseconds()}
+		if err != nil {
+			result.Status = statusBad
+			result.Error = err.Error()
+			healthy = false
+		}
+
+		results[name] = result
+	}
+
+	return results, healthy
+}
+
+func writeReport(w http.ResponseWriter, rep report, healthy bool) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if !healthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	_ = json.NewEncoder(w).Encode(rep)
+}
+
+// LivezHandler reports whether the process itself is alive. It ignores
+// registered Checkers and the draining flag: a pod should only be
+// restarted if it has actually stopped making progress, not merely
+// because it is draining ahead of a graceful shutdown.
+func (r *Registry) LivezHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		writeReport(w, report{Status: statusOK}, true)
+	}
+}
+
+// ReadyzHandler reports whether the process should keep receiving
+// traffic: every registered Checker must pass, and the registry must not
+// be draining.
+func (r *Registry) ReadyzHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		results, healthy := r.runChecks(req.Context())
+
+		r.mu.RLock()
+		draining := r.draining
+		r.mu.RUnlock()
+
+		if draining {
+			healthy = false
+		}
+
+		status := statusOK
+		if !healthy {
+			status = statusBad
+		}
+
+		writeReport(w, report{Status: status, Checks: results}, healthy)
+	}
+}
+
+// HealthzHandler aggregates LivezHandler and ReadyzHandler's checks into
+// a single endpoint.
+func (r *Registry) HealthzHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		results, healthy := r.runChecks(req.Context())
+
+		status := statusOK
+		if !healthy {
+			status = statusBad
+		}
+
+		writeReport(w, report{Status: status, Checks: results}, healthy)
+	}
+}
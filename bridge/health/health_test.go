@@ -0,0 +1,66 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestRegistry_ReadyzHandler_AllPass(t *testing.T) {
+	r := NewRegistry()
+	r.Register("rpc", CheckerFunc(func(ctx context.Context) error { return nil }))
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	rec := httptest.NewRecorder()
+
+	r.ReadyzHandler()(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestRegistry_ReadyzHandler_FailingChecker(t *testing.T) {
+	r := NewRegistry()
+	r.Register("rpc", CheckerFunc(func(ctx context.Context) error { return errors.New("unreachable") }))
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	rec := httptest.NewRecorder()
+
+	r.ReadyzHandler()(rec, req)
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusServiceUnavailable)
+	}
+}
+
+func TestRegistry_ReadyzHandler_Draining(t *testing.T) {
+	r := NewRegistry()
+	r.Register("rpc", CheckerFunc(func(ctx context.Context) error { return nil }))
+	r.Drain()
+
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	rec := httptest.NewRecorder()
+
+	r.ReadyzHandler()(rec, req)
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want %d once draining", rec.Code, http.StatusServiceUnavailable)
+	}
+}
+
+func TestRegistry_LivezHandler_IgnoresDraining(t *testing.T) {
+	r := NewRegistry()
+	r.Drain()
+
+	req := httptest.NewRequest(http.MethodGet, "/livez", nil)
+	rec := httptest.NewRecorder()
+
+	r.LivezHandler()(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d; /livez should stay healthy while draining", rec.Code, http.StatusOK)
+	}
+}
@@ -0,0 +1,41 @@
+package helper
+
+import (
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+// Providers bundles a TracerProvider and MeterProvider so packages under
+// bridge/ and helper/ can create spans and counters without importing
+// OTel directly. Unlike go.opentelemetry.io/otel's package-level
+// otel.Tracer/otel.Meter, Providers is a plain value owned by whatever
+// constructed it (a *bridge/cmd.BridgeContext, in practice) - there is no
+// global to mutate, so two instances in the same process never clobber
+// each other's providers. The zero value is safe to use and returns
+// no-op tracers/meters.
+type Providers struct {
+	TracerProvider trace.TracerProvider
+	MeterProvider  metric.MeterProvider
+}
+
+// Tracer returns a tracer registered under name against p's
+// TracerProvider, or a no-op tracer if p.TracerProvider is nil.
+func (p Providers) Tracer(name string) trace.Tracer {
+	if p.TracerProvider == nil {
+		return tracenoop.NewTracerProvider().Tracer(name)
+	}
+
+	return p.TracerProvider.Tracer(name)
+}
+
+// Meter returns a meter registered under name against p's
+// MeterProvider, or a no-op meter if p.MeterProvider is nil.
+func (p Providers) Meter(name string) metric.Meter {
+	if p.MeterProvider == nil {
+		return metricnoop.NewMeterProvider().Meter(name)
+	}
+
+	return p.MeterProvider.Meter(name)
+}